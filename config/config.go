@@ -4,17 +4,26 @@ import (
 	"flag"
 
 	"github.com/ian-kent/envconf"
+	"github.com/mailhog/logging"
 )
 
 func DefaultConfig() *Config {
 	return &Config{
-		AuthFile: "",
+		AuthFile:  "",
+		TokenFile: "",
+		LogFormat: "text",
+		LogLevel:  "info",
 	}
 }
 
 type Config struct {
-	AuthFile string
-	WebPath  string
+	AuthFile  string
+	TokenFile string
+	WebPath   string
+
+	LogFormat     string
+	LogLevel      string
+	LogUnredacted bool
 }
 
 var cfg = DefaultConfig()
@@ -27,10 +36,22 @@ func Configure() *Config {
 		cfg.WebPath = "/" + cfg.WebPath
 	}
 
+	if err := logging.SetFormat(cfg.LogFormat); err != nil {
+		logging.New("config").Fatalf("%s", err)
+	}
+	if err := logging.SetLevels(cfg.LogLevel); err != nil {
+		logging.New("config").Fatalf("%s", err)
+	}
+	logging.SetRedact(!cfg.LogUnredacted)
+
 	return cfg
 }
 
 func RegisterFlags() {
 	flag.StringVar(&cfg.AuthFile, "auth-file", envconf.FromEnvP("MH_AUTH_FILE", "").(string), "A username:bcryptpw mapping file")
+	flag.StringVar(&cfg.TokenFile, "api-tokens", envconf.FromEnvP("MH_API_TOKENS", "").(string), "A name:role:token API token file")
+	flag.StringVar(&cfg.LogFormat, "log-format", envconf.FromEnvP("MH_LOG_FORMAT", "text").(string), "Log format, text or json")
+	flag.StringVar(&cfg.LogLevel, "log-level", envconf.FromEnvP("MH_LOG_LEVEL", "info").(string), "Log level, optionally with levels for subsystems, e.g. 'warn,smtp=debug'")
+	flag.BoolVar(&cfg.LogUnredacted, "log-unredacted", envconf.FromEnvP("MH_LOG_UNREDACTED", false).(bool), "Include message bodies and authentication secrets in logs")
 	flag.StringVar(&cfg.WebPath, "ui-web-path", envconf.FromEnvP("MH_UI_WEB_PATH", "").(string), "WebPath under which the UI is served (without leading or trailing slashes), e.g. 'mailhog'. Value defaults to ''")
 }
@@ -8,15 +8,16 @@ import (
 	gohttp "net/http"
 
 	"github.com/gorilla/pat"
-	"github.com/ian-kent/go-log/log"
 	"github.com/mailhog/MailHog-Server/api"
 	cfgapi "github.com/mailhog/MailHog-Server/config"
+	"github.com/mailhog/MailHog-Server/loadgen"
 	"github.com/mailhog/MailHog-Server/smtp"
 	"github.com/mailhog/MailHog-UI/assets"
 	cfgui "github.com/mailhog/MailHog-UI/config"
 	"github.com/mailhog/MailHog-UI/web"
 	cfgcom "github.com/mailhog/MailHog/config"
 	"github.com/mailhog/http"
+	"github.com/mailhog/logging"
 	"github.com/mailhog/mhsendmail/cmd"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -25,6 +26,8 @@ var apiconf *cfgapi.Config
 var uiconf *cfgui.Config
 var comconf *cfgcom.Config
 var exitCh chan int
+
+var log = logging.New("mailhog")
 var version string
 
 func configure() {
@@ -32,12 +35,17 @@ func configure() {
 	cfgapi.RegisterFlags()
 	cfgui.RegisterFlags()
 	flag.Parse()
+	// configures logging, so must be first
+	comconf = cfgcom.Configure()
 	apiconf = cfgapi.Configure()
 	uiconf = cfgui.Configure()
-	comconf = cfgcom.Configure()
 
 	apiconf.WebPath = comconf.WebPath
 	uiconf.WebPath = comconf.WebPath
+	apiconf.Version = version
+	if uiconf.UIBindAddr != apiconf.APIBindAddr {
+		apiconf.UIBindAddr = uiconf.UIBindAddr
+	}
 }
 
 func main() {
@@ -56,6 +64,12 @@ func main() {
 		return
 	}
 
+	if len(os.Args) > 1 && os.Args[1] == "loadgen" {
+		os.Args = append([]string{os.Args[0]}, os.Args[2:]...)
+		loadgen.Go()
+		return
+	}
+
 	if len(os.Args) > 1 && os.Args[1] == "bcrypt" {
 		var pw string
 		if len(os.Args) > 2 {
@@ -77,6 +91,9 @@ func main() {
 	if comconf.AuthFile != "" {
 		http.AuthFile(comconf.AuthFile)
 	}
+	if comconf.TokenFile != "" {
+		http.TokenFile(comconf.TokenFile)
+	}
 
 	exitCh = make(chan int)
 	if uiconf.UIBindAddr == apiconf.APIBindAddr {
@@ -98,7 +115,7 @@ func main() {
 	go smtp.Listen(apiconf, exitCh)
 
 	<-exitCh
-	log.Printf("Received exit signal")
+	log.Infof("Received exit signal")
 }
 
 /*